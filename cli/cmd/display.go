@@ -8,6 +8,7 @@ import (
 	"image/color"
 	"log"
 
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/spi/spireg"
 	"periph.io/x/host/v3"
@@ -19,13 +20,18 @@ type DisplayCommand struct {
 	fs         *flag.FlagSet
 	spi        string
 	dc         string
+	reset      string
 	color      int
 	offsetLeft int
 	offsetTop  int
 	width      int
 	height     int
+	rotation   int
+	variant    string
 
-	colorRGBA color.RGBA
+	colorRGBA   color.RGBA
+	orientation st7735.Orientation
+	opts        st7735.Opts
 }
 
 func NewDisplayCommand() *DisplayCommand {
@@ -35,11 +41,14 @@ func NewDisplayCommand() *DisplayCommand {
 
 	c.fs.StringVar(&c.spi, "spi", "", "SPI device (SPI0.1)")
 	c.fs.StringVar(&c.dc, "dc", "", "dc pin (9)")
+	c.fs.StringVar(&c.reset, "reset", "", "reset pin, optional (24)")
 	c.fs.IntVar(&c.color, "color", 0, "Color to set in rgb (0x000000-0xffffff)")
 	c.fs.IntVar(&c.offsetLeft, "offset-left", 0, "Offset from the left")
 	c.fs.IntVar(&c.offsetTop, "offset-top", 0, "Offset from the top")
 	c.fs.IntVar(&c.width, "width", 80, "Width")
 	c.fs.IntVar(&c.height, "height", 160, "Height")
+	c.fs.IntVar(&c.rotation, "rotation", 0, "Rotation: 0=portrait, 1=landscape, 2=inverted portrait, 3=inverted landscape")
+	c.fs.StringVar(&c.variant, "variant", "green", "Panel variant: green, red, black, 144green, 80x160")
 
 	return c
 }
@@ -62,9 +71,20 @@ func (c *DisplayCommand) Init(args []string) error {
 	}
 
 	if c.color < 0 || c.color > 0xffffff {
-		return errors.New(fmt.Sprintf("Color out of range: %s", c.color))
+		return fmt.Errorf("Color out of range: %d", c.color)
 	}
 
+	if c.rotation < 0 || c.rotation > 3 {
+		return errors.New(fmt.Sprintf("Rotation out of range: %d", c.rotation))
+	}
+	c.orientation = st7735.Orientation(c.rotation)
+
+	variant, err := parseVariant(c.variant)
+	if err != nil {
+		return err
+	}
+	c.opts = st7735.DefaultOptsForVariant(variant)
+
 	c.colorRGBA = color.RGBA{uint8(c.color >> 16), uint8((c.color >> 8) & 0xff), uint8(c.color & 0xff), 0}
 	return nil
 }
@@ -88,11 +108,23 @@ func (c *DisplayCommand) Execute() error {
 		return errors.New(fmt.Sprintf("dc pin not found: %s", c.dc))
 	}
 
-	dev, err := st7735.New(conn, dcPin, nil, nil, &st7735.DefaultOpts)
+	var resetPin gpio.PinOut
+	if len(c.reset) > 0 {
+		resetPin = gpioreg.ByName(c.reset)
+		if resetPin == nil {
+			return errors.New(fmt.Sprintf("reset pin not found: %s", c.reset))
+		}
+	}
+
+	dev, err := st7735.New(conn, dcPin, resetPin, nil, &c.opts)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if err := dev.SetOrientation(c.orientation); err != nil {
+		log.Fatal(err)
+	}
+
 	bounds := image.Rectangle{Min: image.Point{0, 0}, Max: image.Point{c.width, c.height}}
 	img := image.NewRGBA(bounds)
 	for x := bounds.Min.X; x < bounds.Max.X; x++ {
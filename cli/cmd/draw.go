@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+
+	"golang.org/x/image/font/basicfont"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/host/v3"
+
+	"github.com/asssaf/st7735-go/st7735"
+	"github.com/asssaf/st7735-go/st7735/gfx"
+)
+
+type DrawCommand struct {
+	fs      *flag.FlagSet
+	spi     string
+	dc      string
+	variant string
+	color   int
+
+	line  string
+	rect  string
+	fill  bool
+	text  string
+	textX int
+	textY int
+
+	colorRGBA color.RGBA
+	opts      st7735.Opts
+}
+
+func NewDrawCommand() *DrawCommand {
+	c := &DrawCommand{
+		fs: flag.NewFlagSet("draw", flag.ExitOnError),
+	}
+
+	c.fs.StringVar(&c.spi, "spi", "", "SPI device (SPI0.1)")
+	c.fs.StringVar(&c.dc, "dc", "", "dc pin (9)")
+	c.fs.StringVar(&c.variant, "variant", "green", "Panel variant: green, red, black, 144green, 80x160")
+	c.fs.IntVar(&c.color, "color", 0xffffff, "Color to draw in rgb (0x000000-0xffffff)")
+	c.fs.StringVar(&c.line, "line", "", "Draw a line: x0,y0,x1,y1")
+	c.fs.StringVar(&c.rect, "rect", "", "Draw a rectangle: x,y,width,height")
+	c.fs.BoolVar(&c.fill, "fill", false, "Fill the rectangle instead of outlining it")
+	c.fs.StringVar(&c.text, "text", "", "Text to draw")
+	c.fs.IntVar(&c.textX, "text-x", 0, "Text origin X")
+	c.fs.IntVar(&c.textY, "text-y", 10, "Text origin Y (baseline)")
+
+	return c
+}
+
+func (c *DrawCommand) Name() string {
+	return c.fs.Name()
+}
+
+func (c *DrawCommand) Init(args []string) error {
+	if err := c.fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(c.spi) == 0 {
+		return errors.New("SPI device must be provided with the -spi flag")
+	}
+
+	if len(c.dc) == 0 {
+		return errors.New("dc pin must be provided with the -dc flag")
+	}
+
+	if c.color < 0 || c.color > 0xffffff {
+		return fmt.Errorf("Color out of range: %d", c.color)
+	}
+	c.colorRGBA = color.RGBA{uint8(c.color >> 16), uint8((c.color >> 8) & 0xff), uint8(c.color & 0xff), 0xff}
+
+	variant, err := parseVariant(c.variant)
+	if err != nil {
+		return err
+	}
+	c.opts = st7735.DefaultOptsForVariant(variant)
+
+	return nil
+}
+
+func (c *DrawCommand) Execute() error {
+	fmt.Printf("Drawing to display\n")
+
+	// Make sure periph is initialized.
+	if _, err := host.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := spireg.Open(c.spi)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	dcPin := gpioreg.ByName(c.dc)
+	if dcPin == nil {
+		return errors.New(fmt.Sprintf("dc pin not found: %s", c.dc))
+	}
+
+	dev, err := st7735.New(conn, dcPin, nil, nil, &c.opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fb := gfx.NewFramebuffer(int(c.opts.Width), int(c.opts.Height))
+
+	if c.line != "" {
+		x0, y0, x1, y1, err := parseFourInts(c.line)
+		if err != nil {
+			return fmt.Errorf("invalid -line: %w", err)
+		}
+		fb.DrawLine(x0, y0, x1, y1, c.colorRGBA)
+	}
+
+	if c.rect != "" {
+		x, y, w, h, err := parseFourInts(c.rect)
+		if err != nil {
+			return fmt.Errorf("invalid -rect: %w", err)
+		}
+		if c.fill {
+			fb.FillRect(x, y, w, h, c.colorRGBA)
+		} else {
+			fb.DrawRect(x, y, w, h, c.colorRGBA)
+		}
+	}
+
+	if c.text != "" {
+		fb.DrawText(c.textX, c.textY, c.text, basicfont.Face7x13, c.colorRGBA)
+	}
+
+	return dev.Flush(fb)
+}
+
+func parseFourInts(s string) (a, b, cc, d int, err error) {
+	_, err = fmt.Sscanf(s, "%d,%d,%d,%d", &a, &b, &cc, &d)
+	return a, b, cc, d, err
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/bmp"
+
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/host/v3"
+
+	"github.com/asssaf/st7735-go/st7735"
+)
+
+type ImageCommand struct {
+	fs         *flag.FlagSet
+	spi        string
+	dc         string
+	file       string
+	fit        string
+	rotation   int
+	offsetLeft int
+	offsetTop  int
+	variant    string
+
+	orientation st7735.Orientation
+	opts        st7735.Opts
+}
+
+func NewImageCommand() *ImageCommand {
+	c := &ImageCommand{
+		fs: flag.NewFlagSet("image", flag.ExitOnError),
+	}
+
+	c.fs.StringVar(&c.spi, "spi", "", "SPI device (SPI0.1)")
+	c.fs.StringVar(&c.dc, "dc", "", "dc pin (9)")
+	c.fs.StringVar(&c.file, "file", "", "BMP or PNG file to display")
+	c.fs.StringVar(&c.fit, "fit", "none", "How to fit the image to the panel: none, stretch, contain, cover")
+	c.fs.IntVar(&c.rotation, "rotation", 0, "Rotation: 0=portrait, 1=landscape, 2=inverted portrait, 3=inverted landscape")
+	c.fs.IntVar(&c.offsetLeft, "offset-left", 0, "Offset from the left")
+	c.fs.IntVar(&c.offsetTop, "offset-top", 0, "Offset from the top")
+	c.fs.StringVar(&c.variant, "variant", "green", "Panel variant: green, red, black, 144green, 80x160")
+
+	return c
+}
+
+func (c *ImageCommand) Name() string {
+	return c.fs.Name()
+}
+
+func (c *ImageCommand) Init(args []string) error {
+	if err := c.fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(c.spi) == 0 {
+		return errors.New("SPI device must be provided with the -spi flag")
+	}
+
+	if len(c.dc) == 0 {
+		return errors.New("dc pin must be provided with the -dc flag")
+	}
+
+	if len(c.file) == 0 {
+		return errors.New("image file must be provided with the -file flag")
+	}
+
+	switch c.fit {
+	case "none", "stretch", "contain", "cover":
+	default:
+		return errors.New(fmt.Sprintf("unknown -fit value: %s", c.fit))
+	}
+
+	if c.rotation < 0 || c.rotation > 3 {
+		return errors.New(fmt.Sprintf("Rotation out of range: %d", c.rotation))
+	}
+	c.orientation = st7735.Orientation(c.rotation)
+
+	variant, err := parseVariant(c.variant)
+	if err != nil {
+		return err
+	}
+	c.opts = st7735.DefaultOptsForVariant(variant)
+
+	return nil
+}
+
+func (c *ImageCommand) Execute() error {
+	fmt.Printf("Displaying image %s\n", c.file)
+
+	img, err := decodeImageFile(c.file)
+	if err != nil {
+		return err
+	}
+
+	// Make sure periph is initialized.
+	if _, err := host.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := spireg.Open(c.spi)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	dcPin := gpioreg.ByName(c.dc)
+	if dcPin == nil {
+		return errors.New(fmt.Sprintf("dc pin not found: %s", c.dc))
+	}
+
+	dev, err := st7735.New(conn, dcPin, nil, nil, &c.opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := dev.SetOrientation(c.orientation); err != nil {
+		log.Fatal(err)
+	}
+
+	fitted := fitImage(img, dev.Bounds().Size(), c.fit)
+
+	return dev.DisplayImage(c.offsetLeft, c.offsetTop, fitted)
+}
+
+// decodeImageFile decodes a BMP or PNG file, picked by extension, into an
+// *image.RGBA.
+func decodeImageFile(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var img image.Image
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bmp":
+		img, err = bmp.Decode(f)
+	case ".png":
+		img, err = png.Decode(f)
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported image extension: %s", path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba, nil
+}
+
+// fitImage resizes/crops img to size according to fit, one of
+// "none"/"stretch"/"contain"/"cover".
+func fitImage(img *image.RGBA, size image.Point, fit string) *image.RGBA {
+	if fit == "none" {
+		return img
+	}
+
+	sb := img.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+
+	var scaleW, scaleH float64
+	switch fit {
+	case "stretch":
+		scaleW = float64(size.X) / float64(sw)
+		scaleH = float64(size.Y) / float64(sh)
+	case "contain":
+		s := minFloat(float64(size.X)/float64(sw), float64(size.Y)/float64(sh))
+		scaleW, scaleH = s, s
+	case "cover":
+		s := maxFloat(float64(size.X)/float64(sw), float64(size.Y)/float64(sh))
+		scaleW, scaleH = s, s
+	}
+
+	dw := int(float64(sw) * scaleW)
+	dh := int(float64(sh) * scaleH)
+
+	scaled := resizeNearest(img, dw, dh)
+
+	if fit == "contain" {
+		out := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+		offset := image.Pt((size.X-dw)/2, (size.Y-dh)/2)
+		draw.Draw(out, image.Rectangle{Min: offset, Max: offset.Add(image.Pt(dw, dh))}, scaled, image.Point{}, draw.Src)
+		return out
+	}
+
+	if fit == "cover" {
+		out := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+		offset := image.Pt((dw-size.X)/2, (dh-size.Y)/2)
+		draw.Draw(out, out.Bounds(), scaled, offset, draw.Src)
+		return out
+	}
+
+	return scaled
+}
+
+// resizeNearest resizes img to width x height using nearest-neighbor
+// sampling.
+func resizeNearest(img *image.RGBA, width, height int) *image.RGBA {
+	sb := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy := sb.Min.Y + y*sb.Dy()/height
+		for x := 0; x < width; x++ {
+			sx := sb.Min.X + x*sb.Dx()/width
+			out.Set(x, y, img.At(sx, sy))
+		}
+	}
+	return out
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func solidImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestResizeNearestDimensions(t *testing.T) {
+	src := solidImage(4, 2, color.RGBA{0xff, 0, 0, 0xff})
+	out := resizeNearest(src, 8, 6)
+	if got := out.Bounds().Size(); got.X != 8 || got.Y != 6 {
+		t.Fatalf("resizeNearest size = %v, want (8,6)", got)
+	}
+}
+
+func TestFitImageNone(t *testing.T) {
+	src := solidImage(10, 20, color.RGBA{1, 2, 3, 0xff})
+	out := fitImage(src, image.Pt(40, 40), "none")
+	if out != src {
+		t.Fatalf("fitImage with \"none\" should return img unchanged")
+	}
+}
+
+func TestFitImageStretch(t *testing.T) {
+	src := solidImage(10, 20, color.RGBA{1, 2, 3, 0xff})
+	out := fitImage(src, image.Pt(40, 40), "stretch")
+	if got := out.Bounds().Size(); got.X != 40 || got.Y != 40 {
+		t.Fatalf("fitImage stretch size = %v, want (40,40)", got)
+	}
+}
+
+func TestFitImageContainPreservesAspect(t *testing.T) {
+	// 10x20 fit into a 40x40 box at "contain" should scale to 20x40,
+	// centered, and be letterboxed to the full 40x40 box.
+	src := solidImage(10, 20, color.RGBA{1, 2, 3, 0xff})
+	out := fitImage(src, image.Pt(40, 40), "contain")
+	if got := out.Bounds().Size(); got.X != 40 || got.Y != 40 {
+		t.Fatalf("fitImage contain size = %v, want (40,40)", got)
+	}
+	// The scaled content should span the full height and be centered
+	// horizontally, leaving transparent columns on either side.
+	if r, _, _, a := out.At(0, 0).RGBA(); a != 0 || r != 0 {
+		t.Fatalf("fitImage contain: expected letterboxed corner to be empty, got r=%d a=%d", r, a)
+	}
+}
+
+func TestFitImageCoverFillsBox(t *testing.T) {
+	// 10x20 fit into a 40x40 box at "cover" should fill the entire box,
+	// cropping the taller dimension.
+	src := solidImage(10, 20, color.RGBA{1, 2, 3, 0xff})
+	out := fitImage(src, image.Pt(40, 40), "cover")
+	if got := out.Bounds().Size(); got.X != 40 || got.Y != 40 {
+		t.Fatalf("fitImage cover size = %v, want (40,40)", got)
+	}
+	if _, _, _, a := out.At(0, 0).RGBA(); a == 0 {
+		t.Fatalf("fitImage cover: corner should be filled, got transparent")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/spi/spireg"
 	"periph.io/x/host/v3"
@@ -14,9 +15,13 @@ import (
 )
 
 type InitCommand struct {
-	fs  *flag.FlagSet
-	spi string
-	dc  string
+	fs      *flag.FlagSet
+	spi     string
+	dc      string
+	reset   string
+	variant string
+
+	opts st7735.Opts
 }
 
 func NewInitCommand() *InitCommand {
@@ -26,6 +31,8 @@ func NewInitCommand() *InitCommand {
 
 	c.fs.StringVar(&c.spi, "spi", "", "SPI device (SPI0.1)")
 	c.fs.StringVar(&c.dc, "dc", "", "dc pin (9)")
+	c.fs.StringVar(&c.reset, "reset", "", "reset pin, optional (24)")
+	c.fs.StringVar(&c.variant, "variant", "green", "Panel variant: green, red, black, 144green, 80x160")
 
 	return c
 }
@@ -49,6 +56,12 @@ func (c *InitCommand) Init(args []string) error {
 		return errors.New("dc pin must be provided with the -dc flag")
 	}
 
+	variant, err := parseVariant(c.variant)
+	if err != nil {
+		return err
+	}
+	c.opts = st7735.DefaultOptsForVariant(variant)
+
 	return nil
 }
 
@@ -71,7 +84,15 @@ func (c *InitCommand) Execute() error {
 		return errors.New(fmt.Sprintf("dc pin not found: %s", c.dc))
 	}
 
-	dev, err := st7735.New(conn, dcPin, nil, nil, &st7735.DefaultOpts)
+	var resetPin gpio.PinOut
+	if len(c.reset) > 0 {
+		resetPin = gpioreg.ByName(c.reset)
+		if resetPin == nil {
+			return errors.New(fmt.Sprintf("reset pin not found: %s", c.reset))
+		}
+	}
+
+	dev, err := st7735.New(conn, dcPin, resetPin, nil, &c.opts)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
 	"periph.io/x/conn/v3/spi/spireg"
 	"periph.io/x/host/v3"
@@ -14,9 +15,10 @@ import (
 )
 
 type PowersaveCommand struct {
-	fs  *flag.FlagSet
-	spi string
-	dc  string
+	fs    *flag.FlagSet
+	spi   string
+	dc    string
+	reset string
 }
 
 func NewPowersaveCommand() *PowersaveCommand {
@@ -26,6 +28,7 @@ func NewPowersaveCommand() *PowersaveCommand {
 
 	c.fs.StringVar(&c.spi, "spi", "", "SPI device (SPI0.1)")
 	c.fs.StringVar(&c.dc, "dc", "", "dc pin (9)")
+	c.fs.StringVar(&c.reset, "reset", "", "reset pin, optional (24)")
 
 	return c
 }
@@ -71,7 +74,15 @@ func (c *PowersaveCommand) Execute() error {
 		return errors.New(fmt.Sprintf("dc pin not found: %s", c.dc))
 	}
 
-	dev, err := st7735.New(conn, dcPin, nil, nil, &st7735.DefaultOpts)
+	var resetPin gpio.PinOut
+	if len(c.reset) > 0 {
+		resetPin = gpioreg.ByName(c.reset)
+		if resetPin == nil {
+			return errors.New(fmt.Sprintf("reset pin not found: %s", c.reset))
+		}
+	}
+
+	dev, err := st7735.New(conn, dcPin, resetPin, nil, &st7735.DefaultOpts)
 	if err != nil {
 		log.Fatal(err)
 	}
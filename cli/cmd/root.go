@@ -18,6 +18,9 @@ func Execute() error {
 		NewBacklightOnCommand(),
 		NewBacklightOffCommand(),
 		NewDisplayCommand(),
+		NewDrawCommand(),
+		NewImageCommand(),
+		NewPowersaveCommand(),
 	}
 
 	flag.Usage = func() {
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/asssaf/st7735-go/st7735"
+)
+
+// parseVariant maps the -variant flag value to a st7735.Variant.
+func parseVariant(name string) (st7735.Variant, error) {
+	switch name {
+	case "", "green":
+		return st7735.VariantGreenTab, nil
+	case "red":
+		return st7735.VariantRedTab, nil
+	case "black":
+		return st7735.VariantBlackTab, nil
+	case "144green":
+		return st7735.Variant144GreenTab, nil
+	case "80x160":
+		return st7735.Variant80x160, nil
+	default:
+		return 0, fmt.Errorf("unknown variant: %s", name)
+	}
+}
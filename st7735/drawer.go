@@ -0,0 +1,75 @@
+package st7735
+
+import (
+	"image"
+	"image/color"
+)
+
+// rgb565Color is the color.Color the ST7735 actually stores, so Dev can
+// report a ColorModel that round-trips through the panel's native
+// precision instead of claiming it can display full 24-bit color.
+type rgb565Color uint16
+
+func (c rgb565Color) RGBA() (r, g, b, a uint32) {
+	r = uint32(c&0xF800) | uint32(c&0xF800)>>5 | uint32(c&0xF800)>>10
+	g = uint32(c&0x07E0)<<5 | uint32(c&0x07E0)>>1
+	b = uint32(c&0x001F)<<11 | uint32(c&0x001F)<<6 | uint32(c&0x001F)<<1
+	return r, g, b, 0xffff
+}
+
+// rgb565Model is the color.Model reported by Dev.ColorModel.
+var rgb565Model = color.ModelFunc(func(c color.Color) color.Color {
+	if cc, ok := c.(rgb565Color); ok {
+		return cc
+	}
+	return rgb565Color(ColorToRGB565(c))
+})
+
+// ColorModel implements periph.io/x/conn/v3/display.Drawer.
+func (d *Dev) ColorModel() color.Model {
+	return rgb565Model
+}
+
+// Bounds implements periph.io/x/conn/v3/display.Drawer. It reflects the
+// current Orientation, so a rotated Dev reports its rotated dimensions.
+func (d *Dev) Bounds() image.Rectangle {
+	return image.Rect(0, 0, int(d.width), int(d.height))
+}
+
+// Draw implements periph.io/x/conn/v3/display.Drawer. It accepts any
+// image.Image, not just *image.RGBA, converts pixel-by-pixel to RGB565 and
+// writes only the intersection of r, d.Bounds() and src's own bounds via a
+// single windowed RAMWR.
+func (d *Dev) Draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	// dx/dy translate a destination coordinate into src's coordinate
+	// space; fixed before any clipping of r below.
+	dx := sp.X - r.Min.X
+	dy := sp.Y - r.Min.Y
+
+	r = r.Intersect(d.Bounds())
+	r = r.Intersect(src.Bounds().Sub(image.Point{X: dx, Y: dy}))
+	if r.Empty() {
+		return nil
+	}
+
+	if err := d.SetWindow(r.Min.X, r.Min.Y, r.Max.X-1, r.Max.Y-1); err != nil {
+		return err
+	}
+
+	return d.sendData(encodeRGB565RowMajor(r, src, dx, dy))
+}
+
+// encodeRGB565RowMajor converts the portion of src covered by r (offset by
+// dx/dy into src's coordinate space) into the big-endian RGB565 byte stream
+// RAMWR expects: row major starting at the top-left, matching
+// gfx.Framebuffer's own byte layout and FlushRect's send order.
+func encodeRGB565RowMajor(r image.Rectangle, src image.Image, dx, dy int) []byte {
+	buf := make([]byte, 0, r.Dx()*r.Dy()*2)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			v := ColorToRGB565(src.At(x+dx, y+dy))
+			buf = append(buf, byte(v>>8), byte(v))
+		}
+	}
+	return buf
+}
@@ -0,0 +1,96 @@
+package st7735
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/asssaf/st7735-go/st7735/gfx"
+)
+
+func TestEncodeRGB565RowMajorOrder(t *testing.T) {
+	// 2x3 image with a distinct color per pixel so the send order is
+	// unambiguous from the output bytes alone.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	colors := [][2]color.RGBA{
+		{{0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0xff}},
+		{{0x00, 0x00, 0xff, 0xff}, {0xff, 0xff, 0x00, 0xff}},
+		{{0x00, 0xff, 0xff, 0xff}, {0xff, 0x00, 0xff, 0xff}},
+	}
+	for y, row := range colors {
+		for x, c := range row {
+			img.Set(x, y, c)
+		}
+	}
+
+	got := encodeRGB565RowMajor(img.Bounds(), img, 0, 0)
+
+	var want []byte
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 2; x++ {
+			v := ColorToRGB565(img.At(x, y))
+			want = append(want, byte(v>>8), byte(v))
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("byte %d = %#x, want %#x (row-major order not preserved)", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEncodeRGB565RowMajorMatchesFlushRectLayout(t *testing.T) {
+	// Draw's output for a full-bounds rectangle must agree byte-for-byte
+	// with gfx.Framebuffer's row-major layout that FlushRect sends, since
+	// both are meant to produce the same RAMWR stream for the same pixels.
+	const w, h = 4, 3
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	fb := gfx.NewFramebuffer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{uint8(x * 10), uint8(y * 10), 0x42, 0xff}
+			img.Set(x, y, c)
+			fb.Set(x, y, c)
+		}
+	}
+
+	got := encodeRGB565RowMajor(img.Bounds(), img, 0, 0)
+	want := fb.Bytes()
+
+	if len(got) != len(want) {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("byte %d = %#x, want %#x", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRGB565ColorRoundTrip(t *testing.T) {
+	cases := []color.RGBA{
+		{0xff, 0xff, 0xff, 0xff},
+		{0x00, 0x00, 0x00, 0xff},
+		{0xf8, 0xfc, 0xf8, 0xff}, // exactly representable in 5/6/5 bits
+	}
+
+	for _, c := range cases {
+		v := ColorToRGB565(c)
+		rc := rgb565Color(v)
+		r, g, b, a := rc.RGBA()
+		if a != 0xffff {
+			t.Errorf("RGBA() alpha = %#x, want 0xffff", a)
+		}
+		// Round-tripping through RGB565 and back should reproduce the
+		// original 5/6/5 precision, scaled up to 16 bits per channel.
+		gotR, gotG, gotB := uint8(r>>8), uint8(g>>8), uint8(b>>8)
+		wantV := ColorToRGB565(color.RGBA{gotR, gotG, gotB, 0xff})
+		if wantV != v {
+			t.Errorf("RGBA() round-trip for %+v: got 565 %#04x back as %#04x", c, v, wantV)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package st7735
+
+import (
+	"image"
+
+	"github.com/asssaf/st7735-go/st7735/gfx"
+)
+
+// NewFramebuffer allocates a persistent Framebuffer sized to the device's
+// current (post-rotation) width/height, for use with Flush/FlushRect and
+// Framebuffer.Present.
+func (d *Dev) NewFramebuffer() *gfx.Framebuffer {
+	return gfx.NewFramebuffer(int(d.width), int(d.height))
+}
+
+// Flush pushes the entire framebuffer to the display.
+func (d *Dev) Flush(fb *gfx.Framebuffer) error {
+	return d.FlushRect(fb, fb.Bounds())
+}
+
+// FlushRect pushes only the portion of the framebuffer inside r, issuing a
+// single windowed CASET/RASET/RAMWR for it, so animations don't have to
+// retransmit the full frame every time.
+func (d *Dev) FlushRect(fb *gfx.Framebuffer, r image.Rectangle) error {
+	r = r.Intersect(fb.Bounds())
+	if r.Empty() {
+		return nil
+	}
+
+	if err := d.SetWindow(r.Min.X, r.Min.Y, r.Max.X-1, r.Max.Y-1); err != nil {
+		return err
+	}
+
+	pix := fb.Bytes()
+	rowBytes := r.Dx() * 2
+	buf := make([]byte, 0, rowBytes*r.Dy())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		start := (y*fb.Width + r.Min.X) * 2
+		buf = append(buf, pix[start:start+rowBytes]...)
+	}
+
+	return d.sendData(buf)
+}
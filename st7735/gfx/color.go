@@ -0,0 +1,27 @@
+package gfx
+
+import "image/color"
+
+// color565 is a color.Color backed by a 16-bit RGB565 value, the native
+// pixel format of the ST7735's RAM.
+type color565 uint16
+
+func (c color565) RGBA() (r, g, b, a uint32) {
+	r = uint32(c&0xF800) | uint32(c&0xF800)>>5 | uint32(c&0xF800)>>10
+	g = uint32(c&0x07E0)<<5 | uint32(c&0x07E0)>>1
+	b = uint32(c&0x001F)<<11 | uint32(c&0x001F)<<6 | uint32(c&0x001F)<<1
+	return r, g, b, 0xffff
+}
+
+var color565Model = color.ModelFunc(func(c color.Color) color.Color {
+	if cc, ok := c.(color565); ok {
+		return cc
+	}
+	return color565(toRGB565(c))
+})
+
+// toRGB565 converts any color.Color to a packed RGB565 value.
+func toRGB565(c color.Color) uint16 {
+	r, g, b, _ := c.RGBA()
+	return uint16(r&0xF800) | uint16((g&0xFC00)>>5) | uint16((b&0xF800)>>11)
+}
@@ -0,0 +1,101 @@
+package gfx
+
+import "image"
+
+// TileSize is the edge length, in pixels, of the tiles used to track which
+// parts of a Framebuffer have changed since the last Present.
+const TileSize = 16
+
+// Flusher pushes the pixels of a rectangular region of a Framebuffer to a
+// display. *st7735.Dev satisfies this via its FlushRect method.
+type Flusher interface {
+	FlushRect(fb *Framebuffer, r image.Rectangle) error
+}
+
+func (fb *Framebuffer) markDirty(x, y int) {
+	tx, ty := x/TileSize, y/TileSize
+	fb.dirty[ty*fb.tilesX+tx] = true
+}
+
+func (fb *Framebuffer) markDirtyRect(r image.Rectangle) {
+	r = r.Intersect(fb.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	for ty := r.Min.Y / TileSize; ty <= (r.Max.Y-1)/TileSize; ty++ {
+		for tx := r.Min.X / TileSize; tx <= (r.Max.X-1)/TileSize; tx++ {
+			fb.dirty[ty*fb.tilesX+tx] = true
+		}
+	}
+}
+
+// DirtyRects coalesces adjacent dirty tiles into minimal bounding
+// rectangles and clears the dirty state for them. Within a tile row, runs
+// of dirty tiles are merged horizontally; a run is then merged with the
+// matching run directly below it, so a rectangular dirty area spanning
+// multiple tile rows (the common case for animation) becomes a single
+// rectangle instead of one per tile row.
+func (fb *Framebuffer) DirtyRects() []image.Rectangle {
+	var rects []image.Rectangle
+
+	for ty := 0; ty < fb.tilesY; ty++ {
+		for tx := 0; tx < fb.tilesX; {
+			if !fb.dirty[ty*fb.tilesX+tx] {
+				tx++
+				continue
+			}
+
+			start := tx
+			end := tx
+			for end < fb.tilesX && fb.dirty[ty*fb.tilesX+end] {
+				end++
+			}
+
+			bottom := ty + 1
+			// Only merge when the row below has exactly the same run: every
+			// tile in [start, end) dirty and the tiles just outside it clean.
+			for bottom < fb.tilesY && rowFullyDirty(fb, bottom, start, end) &&
+				(start == 0 || !fb.dirty[bottom*fb.tilesX+start-1]) &&
+				(end == fb.tilesX || !fb.dirty[bottom*fb.tilesX+end]) {
+				bottom++
+			}
+
+			for y := ty; y < bottom; y++ {
+				for x := start; x < end; x++ {
+					fb.dirty[y*fb.tilesX+x] = false
+				}
+			}
+
+			r := image.Rect(start*TileSize, ty*TileSize, end*TileSize, bottom*TileSize)
+			rects = append(rects, r.Intersect(fb.Bounds()))
+			tx = end
+		}
+	}
+
+	return rects
+}
+
+// rowFullyDirty reports whether every tile from start up to (but not
+// including) end in tile row ty is dirty, used by DirtyRects to extend a
+// horizontal run downward.
+func rowFullyDirty(fb *Framebuffer, ty, start, end int) bool {
+	for tx := start; tx < end; tx++ {
+		if !fb.dirty[ty*fb.tilesX+tx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Present flushes every dirty rectangle to d and clears the dirty state,
+// so the next Present only sends what changed since this call.
+func (fb *Framebuffer) Present(d Flusher) error {
+	for _, r := range fb.DirtyRects() {
+		if err := d.FlushRect(fb, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
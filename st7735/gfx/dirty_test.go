@@ -0,0 +1,57 @@
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDirtyRectsMergesHorizontalRun(t *testing.T) {
+	fb := NewFramebuffer(64, 64) // 4x4 tiles
+	fb.markDirtyRect(image.Rect(0, 0, TileSize*3, TileSize))
+
+	rects := fb.DirtyRects()
+	if len(rects) != 1 {
+		t.Fatalf("DirtyRects() = %v, want a single merged rect", rects)
+	}
+	want := image.Rect(0, 0, TileSize*3, TileSize)
+	if rects[0] != want {
+		t.Errorf("DirtyRects()[0] = %v, want %v", rects[0], want)
+	}
+}
+
+func TestDirtyRectsMergesVerticalRows(t *testing.T) {
+	fb := NewFramebuffer(64, 64) // 4x4 tiles
+	fb.markDirtyRect(image.Rect(TileSize, 0, TileSize*3, TileSize*2))
+
+	rects := fb.DirtyRects()
+	if len(rects) != 1 {
+		t.Fatalf("DirtyRects() = %v, want a single rect spanning both tile rows", rects)
+	}
+	want := image.Rect(TileSize, 0, TileSize*3, TileSize*2)
+	if rects[0] != want {
+		t.Errorf("DirtyRects()[0] = %v, want %v", rects[0], want)
+	}
+}
+
+func TestDirtyRectsDoesNotMergeMismatchedSpans(t *testing.T) {
+	fb := NewFramebuffer(64, 64) // 4x4 tiles
+	fb.markDirtyRect(image.Rect(0, 0, TileSize*2, TileSize))
+	fb.markDirtyRect(image.Rect(0, TileSize, TileSize*3, TileSize*2))
+
+	rects := fb.DirtyRects()
+	if len(rects) != 2 {
+		t.Fatalf("DirtyRects() = %v, want two separate rects for mismatched row spans", rects)
+	}
+}
+
+func TestDirtyRectsClearsState(t *testing.T) {
+	fb := NewFramebuffer(32, 32)
+	fb.markDirtyRect(fb.Bounds())
+
+	if rects := fb.DirtyRects(); len(rects) == 0 {
+		t.Fatal("first DirtyRects() call returned nothing, expected the whole framebuffer")
+	}
+	if rects := fb.DirtyRects(); len(rects) != 0 {
+		t.Fatalf("second DirtyRects() call = %v, want none (dirty state should be cleared)", rects)
+	}
+}
@@ -0,0 +1,240 @@
+// Package gfx provides an in-memory RGB565 framebuffer with 2D drawing
+// primitives and text rendering, so callers don't have to reimplement them
+// on top of st7735.Dev.DisplayImage for every animation frame.
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Framebuffer is an in-memory RGB565 pixel buffer. It implements
+// draw.Image so it can be used as the destination of a font.Drawer or
+// the standard image/draw package. It also tracks which TileSize x
+// TileSize tiles have been touched since the last Present, so repeated
+// partial updates don't have to retransmit the whole frame.
+type Framebuffer struct {
+	Width, Height int
+
+	// pix holds Width*Height pixels, 2 bytes each, big-endian RGB565, row
+	// major starting at the top-left, matching the byte order Dev expects
+	// over SPI.
+	pix []byte
+
+	tilesX, tilesY int
+	dirty          []bool
+}
+
+// NewFramebuffer allocates a Framebuffer of the given size, initialized to
+// black.
+func NewFramebuffer(width, height int) *Framebuffer {
+	tilesX := (width + TileSize - 1) / TileSize
+	tilesY := (height + TileSize - 1) / TileSize
+
+	return &Framebuffer{
+		Width:  width,
+		Height: height,
+		pix:    make([]byte, width*height*2),
+		tilesX: tilesX,
+		tilesY: tilesY,
+		dirty:  make([]bool, tilesX*tilesY),
+	}
+}
+
+// Bytes returns the framebuffer's backing RGB565 bytes, ready to send to
+// Dev.Flush/FlushRect.
+func (fb *Framebuffer) Bytes() []byte {
+	return fb.pix
+}
+
+// ColorModel implements image.Image.
+func (fb *Framebuffer) ColorModel() color.Model {
+	return color565Model
+}
+
+// Bounds implements image.Image.
+func (fb *Framebuffer) Bounds() image.Rectangle {
+	return image.Rect(0, 0, fb.Width, fb.Height)
+}
+
+// At implements image.Image.
+func (fb *Framebuffer) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(fb.Bounds())) {
+		return color565(0)
+	}
+
+	i := (y*fb.Width + x) * 2
+	return color565(uint16(fb.pix[i])<<8 | uint16(fb.pix[i+1]))
+}
+
+// Set implements draw.Image. It marks the pixel's tile dirty.
+func (fb *Framebuffer) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(fb.Bounds())) {
+		return
+	}
+
+	v := toRGB565(c)
+	i := (y*fb.Width + x) * 2
+	fb.pix[i] = byte(v >> 8)
+	fb.pix[i+1] = byte(v)
+
+	fb.markDirty(x, y)
+}
+
+// Blit draws src onto the framebuffer with its top-left corner at (x, y),
+// marking every tile it touches dirty.
+func (fb *Framebuffer) Blit(x, y int, src image.Image) {
+	r := image.Rect(x, y, x+src.Bounds().Dx(), y+src.Bounds().Dy())
+	draw.Draw(fb, r, src, src.Bounds().Min, draw.Src)
+	fb.markDirtyRect(r)
+}
+
+// Fill sets every pixel in the framebuffer to c and marks the whole
+// framebuffer dirty.
+func (fb *Framebuffer) Fill(c color.Color) {
+	v := toRGB565(c)
+	hi, lo := byte(v>>8), byte(v)
+	for i := 0; i < len(fb.pix); i += 2 {
+		fb.pix[i] = hi
+		fb.pix[i+1] = lo
+	}
+
+	for i := range fb.dirty {
+		fb.dirty[i] = true
+	}
+}
+
+// DrawPixel sets a single pixel, silently ignoring out-of-bounds
+// coordinates.
+func (fb *Framebuffer) DrawPixel(x, y int, c color.Color) {
+	fb.Set(x, y, c)
+}
+
+// DrawLine draws a line from (x0, y0) to (x1, y1) using Bresenham's
+// algorithm.
+func (fb *Framebuffer) DrawLine(x0, y0, x1, y1 int, c color.Color) {
+	dx := abs(x1 - x0)
+	sx := 1
+	if x0 >= x1 {
+		sx = -1
+	}
+	dy := -abs(y1 - y0)
+	sy := 1
+	if y0 >= y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	for {
+		fb.DrawPixel(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// DrawRect draws the outline of a width x height rectangle with its
+// top-left corner at (x, y).
+func (fb *Framebuffer) DrawRect(x, y, width, height int, c color.Color) {
+	fb.DrawLine(x, y, x+width-1, y, c)
+	fb.DrawLine(x, y+height-1, x+width-1, y+height-1, c)
+	fb.DrawLine(x, y, x, y+height-1, c)
+	fb.DrawLine(x+width-1, y, x+width-1, y+height-1, c)
+}
+
+// FillRect draws a filled width x height rectangle with its top-left
+// corner at (x, y).
+func (fb *Framebuffer) FillRect(x, y, width, height int, c color.Color) {
+	for row := y; row < y+height; row++ {
+		fb.DrawLine(x, row, x+width-1, row, c)
+	}
+}
+
+// DrawCircle draws the outline of a circle centered at (x0, y0) with the
+// given radius, using the midpoint circle algorithm.
+func (fb *Framebuffer) DrawCircle(x0, y0, r int, c color.Color) {
+	x := r
+	y := 0
+	err := 1 - r
+
+	for x >= y {
+		fb.DrawPixel(x0+x, y0+y, c)
+		fb.DrawPixel(x0+y, y0+x, c)
+		fb.DrawPixel(x0-y, y0+x, c)
+		fb.DrawPixel(x0-x, y0+y, c)
+		fb.DrawPixel(x0-x, y0-y, c)
+		fb.DrawPixel(x0-y, y0-x, c)
+		fb.DrawPixel(x0+y, y0-x, c)
+		fb.DrawPixel(x0+x, y0-y, c)
+
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+// FillCircle draws a filled circle centered at (x0, y0) with the given
+// radius.
+func (fb *Framebuffer) FillCircle(x0, y0, r int, c color.Color) {
+	for dy := -r; dy <= r; dy++ {
+		dx := intSqrt(r*r - dy*dy)
+		fb.DrawLine(x0-dx, y0+dy, x0+dx, y0+dy, c)
+	}
+}
+
+// DrawTriangle draws the outline of a triangle with the given vertices.
+func (fb *Framebuffer) DrawTriangle(x0, y0, x1, y1, x2, y2 int, c color.Color) {
+	fb.DrawLine(x0, y0, x1, y1, c)
+	fb.DrawLine(x1, y1, x2, y2, c)
+	fb.DrawLine(x2, y2, x0, y0, c)
+}
+
+// DrawText draws s starting at (x, y) (the font's baseline origin) in the
+// given face and color.
+func (fb *Framebuffer) DrawText(x, y int, s string, face font.Face, c color.Color) {
+	d := &font.Drawer{
+		Dst:  fb,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func intSqrt(v int) int {
+	if v <= 0 {
+		return 0
+	}
+
+	x := v
+	y := (x + 1) / 2
+	for y < x {
+		x = y
+		y = (x + v/x) / 2
+	}
+	return x
+}
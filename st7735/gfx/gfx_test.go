@@ -0,0 +1,103 @@
+package gfx
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestFramebufferSetAt(t *testing.T) {
+	fb := NewFramebuffer(10, 10)
+	fb.Set(3, 4, color.RGBA{0xff, 0x00, 0x00, 0xff})
+
+	r, _, _, _ := fb.At(3, 4).RGBA()
+	if r>>8 != 0xff {
+		t.Errorf("At(3,4) red = %#x, want 0xff", r>>8)
+	}
+	if r2, _, _, _ := fb.At(0, 0).RGBA(); r2 != 0 {
+		t.Errorf("untouched pixel should still be black, got red %#x", r2)
+	}
+}
+
+func TestFramebufferSetOutOfBoundsIgnored(t *testing.T) {
+	fb := NewFramebuffer(4, 4)
+	fb.Set(-1, 0, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	fb.Set(4, 4, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	// Should not panic, and should not mark anything dirty.
+	if got := fb.DirtyRects(); len(got) != 0 {
+		t.Errorf("DirtyRects() after out-of-bounds Set = %v, want none", got)
+	}
+}
+
+func TestFramebufferFillMarksEverythingDirty(t *testing.T) {
+	fb := NewFramebuffer(32, 32)
+	fb.Fill(color.RGBA{0x11, 0x22, 0x33, 0xff})
+
+	rects := fb.DirtyRects()
+	if len(rects) != 1 {
+		t.Fatalf("DirtyRects() after Fill = %v, want a single full-bounds rect", rects)
+	}
+	if rects[0] != fb.Bounds() {
+		t.Errorf("DirtyRects()[0] = %v, want %v", rects[0], fb.Bounds())
+	}
+}
+
+func TestDrawLineEndpoints(t *testing.T) {
+	fb := NewFramebuffer(20, 20)
+	c := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	fb.DrawLine(2, 2, 10, 2, c)
+
+	for x := 2; x <= 10; x++ {
+		if r, _, _, _ := fb.At(x, 2).RGBA(); r>>8 != 0xff {
+			t.Errorf("At(%d, 2) not set by horizontal DrawLine", x)
+		}
+	}
+}
+
+func TestDrawRectOutline(t *testing.T) {
+	fb := NewFramebuffer(20, 20)
+	c := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	fb.DrawRect(1, 1, 5, 5, c)
+
+	// Corners should be set, center should not.
+	corners := [][2]int{{1, 1}, {5, 1}, {1, 5}, {5, 5}}
+	for _, p := range corners {
+		if r, _, _, _ := fb.At(p[0], p[1]).RGBA(); r>>8 != 0xff {
+			t.Errorf("corner (%d,%d) not set by DrawRect outline", p[0], p[1])
+		}
+	}
+	if r, _, _, _ := fb.At(3, 3).RGBA(); r>>8 != 0 {
+		t.Errorf("center of DrawRect outline should be untouched, got red %#x", r>>8)
+	}
+}
+
+func TestFillRectFillsInterior(t *testing.T) {
+	fb := NewFramebuffer(20, 20)
+	c := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	fb.FillRect(2, 2, 4, 4, c)
+
+	for y := 2; y < 6; y++ {
+		for x := 2; x < 6; x++ {
+			if r, _, _, _ := fb.At(x, y).RGBA(); r>>8 != 0xff {
+				t.Errorf("At(%d,%d) not filled by FillRect", x, y)
+			}
+		}
+	}
+}
+
+func TestAbs(t *testing.T) {
+	cases := map[int]int{5: 5, -5: 5, 0: 0}
+	for in, want := range cases {
+		if got := abs(in); got != want {
+			t.Errorf("abs(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestIntSqrt(t *testing.T) {
+	cases := map[int]int{0: 0, -1: 0, 1: 1, 4: 2, 15: 3, 16: 4, 99: 9, 100: 10}
+	for in, want := range cases {
+		if got := intSqrt(in); got != want {
+			t.Errorf("intSqrt(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package st7735
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spitest"
+)
+
+// recordingPort wraps spitest.NewRecordRaw and remembers the frequency/mode
+// it was connected with, so tests can check what New passes through.
+type recordingPort struct {
+	spi.PortCloser
+	gotHz   physic.Frequency
+	gotMode spi.Mode
+}
+
+func (p *recordingPort) Connect(f physic.Frequency, mode spi.Mode, bits int) (spi.Conn, error) {
+	p.gotHz = f
+	p.gotMode = mode
+	return p.PortCloser.Connect(f, mode, bits)
+}
+
+// recordingPin wraps gpiotest.Pin and timestamps every Out call so tests
+// can assert both the level sequence and the delays between them.
+type recordingPin struct {
+	gpiotest.Pin
+
+	mu     sync.Mutex
+	events []recordedLevel
+}
+
+type recordedLevel struct {
+	level gpio.Level
+	at    time.Time
+}
+
+func (p *recordingPin) Out(l gpio.Level) error {
+	p.mu.Lock()
+	p.events = append(p.events, recordedLevel{l, time.Now()})
+	p.mu.Unlock()
+	return p.Pin.Out(l)
+}
+
+func newTestDev(t *testing.T, rst gpio.PinOut) *Dev {
+	t.Helper()
+	spiPort := spitest.NewRecordRaw(io.Discard)
+	dcPin := &gpiotest.Pin{N: "dc"}
+	d, err := New(spiPort, dcPin, rst, nil, &DefaultOpts)
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	return d
+}
+
+func TestHardwareResetToggleSequence(t *testing.T) {
+	rst := &recordingPin{Pin: gpiotest.Pin{N: "rst"}}
+	d := newTestDev(t, rst)
+
+	start := time.Now()
+	if err := d.hardwareReset(); err != nil {
+		t.Fatalf("hardwareReset() = %v", err)
+	}
+
+	if len(rst.events) != 3 {
+		t.Fatalf("rst received %d Out calls, want 3 (high, low, high)", len(rst.events))
+	}
+	wantLevels := []gpio.Level{gpio.High, gpio.Low, gpio.High}
+	for i, want := range wantLevels {
+		if rst.events[i].level != want {
+			t.Errorf("Out call %d = %v, want %v", i, rst.events[i].level, want)
+		}
+	}
+
+	// high -> low should happen immediately; low -> high after >=10ms;
+	// and hardwareReset itself shouldn't return before the final 120ms
+	// settle wait.
+	if gap := rst.events[2].at.Sub(rst.events[1].at); gap < 10*time.Millisecond {
+		t.Errorf("low-to-high gap = %v, want >= 10ms", gap)
+	}
+	if elapsed := time.Since(start); elapsed < 130*time.Millisecond {
+		t.Errorf("hardwareReset() returned after %v, want >= 130ms (10ms low + 120ms settle)", elapsed)
+	}
+}
+
+func TestHardwareResetNoOpWithoutRstPin(t *testing.T) {
+	d := newTestDev(t, nil)
+
+	start := time.Now()
+	if err := d.hardwareReset(); err != nil {
+		t.Fatalf("hardwareReset() = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("hardwareReset() with no rst pin took %v, want a no-op", elapsed)
+	}
+}
+
+func TestNewDefaultsSPIHzAndMode(t *testing.T) {
+	// New must apply DefaultSPIHz/spi.Mode0 when Opts leaves SPIHz/SPIMode
+	// at their zero values.
+	port := &recordingPort{PortCloser: spitest.NewRecordRaw(io.Discard)}
+	dcPin := &gpiotest.Pin{N: "dc"}
+	if _, err := New(port, dcPin, nil, nil, &DefaultOpts); err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	if port.gotHz != DefaultSPIHz {
+		t.Errorf("Connect hz = %v, want DefaultSPIHz (%v)", port.gotHz, DefaultSPIHz)
+	}
+	if port.gotMode != spi.Mode0 {
+		t.Errorf("Connect mode = %v, want spi.Mode0", port.gotMode)
+	}
+}
+
+func TestNewHonorsExplicitSPIHzAndMode(t *testing.T) {
+	port := &recordingPort{PortCloser: spitest.NewRecordRaw(io.Discard)}
+	dcPin := &gpiotest.Pin{N: "dc"}
+	o := DefaultOpts
+	o.SPIHz = 8 * physic.MegaHertz
+	o.SPIMode = spi.Mode3
+	if _, err := New(port, dcPin, nil, nil, &o); err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	if port.gotHz != 8*physic.MegaHertz {
+		t.Errorf("Connect hz = %v, want 8MHz", port.gotHz)
+	}
+	if port.gotMode != spi.Mode3 {
+		t.Errorf("Connect mode = %v, want spi.Mode3", port.gotMode)
+	}
+}
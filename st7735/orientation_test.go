@@ -0,0 +1,38 @@
+package st7735
+
+import "testing"
+
+func TestOrientationMadctl(t *testing.T) {
+	cases := []struct {
+		o    Orientation
+		bgr  bool
+		want byte
+	}{
+		{Portrait, false, ST7735_MADCTL_MX | ST7735_MADCTL_MY},
+		{Portrait, true, ST7735_MADCTL_MX | ST7735_MADCTL_MY | ST7735_MADCTL_BGR},
+		{Landscape, false, ST7735_MADCTL_MV | ST7735_MADCTL_MY},
+		{InvertedPortrait, false, 0},
+		{InvertedLandscape, false, ST7735_MADCTL_MV | ST7735_MADCTL_MX},
+	}
+
+	for _, c := range cases {
+		if got := c.o.madctl(c.bgr); got != c.want {
+			t.Errorf("Orientation(%d).madctl(%v) = %#02x, want %#02x", c.o, c.bgr, got, c.want)
+		}
+	}
+}
+
+func TestOrientationSwapsDimensions(t *testing.T) {
+	cases := map[Orientation]bool{
+		Portrait:          false,
+		Landscape:         true,
+		InvertedPortrait:  false,
+		InvertedLandscape: true,
+	}
+
+	for o, want := range cases {
+		if got := o.swapsDimensions(); got != want {
+			t.Errorf("Orientation(%d).swapsDimensions() = %v, want %v", o, got, want)
+		}
+	}
+}
@@ -2,48 +2,95 @@ package st7735
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"time"
 
 	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/display"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/spi"
 )
 
+// Confirms *Dev implements periph.io/x/conn/v3/display.Drawer (including
+// the conn.Resource methods it embeds) at compile time.
+var _ display.Drawer = (*Dev)(nil)
+
 const (
-	ST7735_SWRESET   = 0x01
-	ST7735_SLPIN     = 0x10
-	ST7735_SLPOUT    = 0x11
-	ST7735_FRMCTR1   = 0xB1
-	ST7735_FRMCTR2   = 0xB2
-	ST7735_FRMCTR3   = 0xB3
-	ST7735_INVCTR    = 0xB4
-	ST7735_INVOFF    = 0x20
-	ST7735_INVON     = 0x21
-	ST7735_PWCTR1    = 0xC0
-	ST7735_PWCTR2    = 0xC1
-	ST7735_PWCTR4    = 0xC3
-	ST7735_PWCTR5    = 0xC4
-	ST7735_VMCTR1    = 0xC5
-	ST7735_MADCTL    = 0x36
-	ST7735_COLMOD    = 0x3A
-	ST7735_CASET     = 0x2A
-	ST7735_RASET     = 0x2B
-	ST7735_RAMWR     = 0x2C
-	ST7735_GMCTRP1   = 0xE0
-	ST7735_GMCTRN1   = 0xE1
-	ST7735_NORON     = 0x13
-	ST7735_DISPON    = 0x29
-	ST7735_TFTWIDTH  = 80
-	ST7735_TFTHEIGHT = 160
-	ST7735_COLS      = 132
-	ST7735_ROWS      = 162
+	ST7735_SWRESET = 0x01
+	ST7735_SLPIN   = 0x10
+	ST7735_SLPOUT  = 0x11
+	ST7735_FRMCTR1 = 0xB1
+	ST7735_FRMCTR2 = 0xB2
+	ST7735_FRMCTR3 = 0xB3
+	ST7735_INVCTR  = 0xB4
+	ST7735_INVOFF  = 0x20
+	ST7735_INVON   = 0x21
+	ST7735_PWCTR1  = 0xC0
+	ST7735_PWCTR2  = 0xC1
+	ST7735_PWCTR4  = 0xC3
+	ST7735_PWCTR5  = 0xC4
+	ST7735_VMCTR1  = 0xC5
+	ST7735_MADCTL  = 0x36
+	ST7735_COLMOD  = 0x3A
+	ST7735_CASET   = 0x2A
+	ST7735_RASET   = 0x2B
+	ST7735_RAMWR   = 0x2C
+	ST7735_GMCTRP1 = 0xE0
+	ST7735_GMCTRN1 = 0xE1
+	ST7735_NORON   = 0x13
+	ST7735_DISPON  = 0x29
+
+	// MADCTL bits, see ST7735_MADCTL
+	ST7735_MADCTL_MY  = 0x80 // row address order
+	ST7735_MADCTL_MX  = 0x40 // column address order
+	ST7735_MADCTL_MV  = 0x20 // row/column exchange
+	ST7735_MADCTL_ML  = 0x10 // vertical refresh order
+	ST7735_MADCTL_BGR = 0x08 // BGR instead of RGB
+	ST7735_MADCTL_MH  = 0x04 // horizontal refresh order
 
 	ChunkSize = 4096
 )
 
+// Orientation selects the rotation applied to the panel via MADCTL.
+type Orientation byte
+
+const (
+	Portrait Orientation = iota
+	Landscape
+	InvertedPortrait
+	InvertedLandscape
+)
+
+// madctl returns the MADCTL value for the orientation, with the given
+// panel variant's color order bit (BGR vs RGB) applied on top.
+func (o Orientation) madctl(bgr bool) byte {
+	var b byte
+	switch o {
+	case Landscape:
+		b = ST7735_MADCTL_MV | ST7735_MADCTL_MY
+	case InvertedPortrait:
+		b = 0
+	case InvertedLandscape:
+		b = ST7735_MADCTL_MV | ST7735_MADCTL_MX
+	default: // Portrait
+		b = ST7735_MADCTL_MX | ST7735_MADCTL_MY
+	}
+
+	if bgr {
+		b |= ST7735_MADCTL_BGR
+	}
+
+	return b
+}
+
+// swapsDimensions reports whether the orientation exchanges rows and columns.
+func (o Orientation) swapsDimensions() bool {
+	return o == Landscape || o == InvertedLandscape
+}
+
 // Dev is a handle to a ST7735
 type Dev struct {
 	c conn.Conn
@@ -56,26 +103,52 @@ type Dev struct {
 	backlight gpio.PinOut
 
 	opts Opts
+
+	// spec is the resolved command table/geometry for opts.Variant.
+	spec variantSpec
+
+	// effective dimensions and offsets after applying opts.Orientation
+	width      byte
+	height     byte
+	offsetLeft byte
+	offsetTop  byte
 }
 
 // Opts contains the configuration fot the ST7735 device
 type Opts struct {
-	Width      byte
-	Height     byte
-	OffsetLeft byte
-	OffsetTop  byte
+	Width       byte
+	Height      byte
+	OffsetLeft  byte
+	OffsetTop   byte
+	Orientation Orientation
+	Variant     Variant
+
+	// SPIHz is the SPI clock speed to connect at. Zero defaults to
+	// DefaultSPIHz.
+	SPIHz physic.Frequency
+	// SPIMode is the SPI mode to connect with. Defaults to spi.Mode0.
+	SPIMode spi.Mode
 }
 
-var DefaultOpts = Opts{
-	Width:      ST7735_TFTWIDTH,
-	Height:     ST7735_TFTHEIGHT,
-	OffsetLeft: (ST7735_COLS - ST7735_TFTWIDTH) / 2,  // (132-80)/2 = 26
-	OffsetTop:  (ST7735_ROWS - ST7735_TFTHEIGHT) / 2, // (162-160)/2 = 1
-}
+// DefaultSPIHz is the SPI clock speed used when Opts.SPIHz is zero. Most
+// ST7735 panels on a Raspberry Pi can be driven considerably faster, but
+// this is a safe default.
+const DefaultSPIHz = 4 * physic.MegaHertz
+
+var DefaultOpts = DefaultOptsForVariant(Variant80x160)
 
 // New opens a handle to a ST7735
 func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, backlight gpio.PinOut, o *Opts) (*Dev, error) {
-	c, err := p.Connect(4000*physic.KiloHertz, spi.Mode0, 8)
+	if err := o.Variant.validate(o.Width, o.Height); err != nil {
+		return nil, err
+	}
+
+	hz := o.SPIHz
+	if hz == 0 {
+		hz = DefaultSPIHz
+	}
+
+	c, err := p.Connect(hz, o.SPIMode, 8)
 
 	if err != nil {
 		return nil, errors.New("could not connect to device")
@@ -87,22 +160,47 @@ func New(p spi.Port, dc gpio.PinOut, rst gpio.PinOut, backlight gpio.PinOut, o *
 		rst:       rst,
 		backlight: backlight,
 		opts:      *o,
+		spec:      o.Variant.spec(),
 	}
+	d.applyOrientation(o.Orientation)
 
 	return d, nil
 }
 
+// hardwareReset toggles rst to bring the panel out of reset: high, low for
+// 10ms, high again, then wait 120ms for the controller to come up, matching
+// the sequence used by the Zephyr and Rust reference drivers. It is a no-op
+// when no rst pin was provided to New.
+func (d *Dev) hardwareReset() error {
+	if d.rst == nil {
+		return nil
+	}
+
+	if err := d.rst.Out(gpio.High); err != nil {
+		return err
+	}
+	if err := d.rst.Out(gpio.Low); err != nil {
+		return err
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := d.rst.Out(gpio.High); err != nil {
+		return err
+	}
+	time.Sleep(120 * time.Millisecond)
+
+	return nil
+}
+
 func (d *Dev) Init() error {
+	if err := d.hardwareReset(); err != nil {
+		return err
+	}
+
 	invert := commandAndData{[]byte{ST7735_INVON}, nil, 0} // don't invert
 	if false {
 		invert = commandAndData{[]byte{ST7735_INVOFF}, nil, 0} // invert
 	}
 
-	width := d.opts.Width
-	height := d.opts.Height
-	offsetLeft := d.opts.OffsetLeft
-	offsetTop := d.opts.OffsetTop
-
 	init := []commandAndData{
 		{[]byte{ST7735_SWRESET}, nil, 150 * time.Millisecond},                   // software reset
 		{[]byte{ST7735_SLPOUT}, nil, 500 * time.Millisecond},                    // out of sleep mode
@@ -116,21 +214,47 @@ func (d *Dev) Init() error {
 		{[]byte{ST7735_PWCTR5}, []byte{0x8A, 0xEE}, 0},                          // power control
 		{[]byte{ST7735_VMCTR1}, []byte{0x0E}, 0},                                // power control
 		invert,
-		{[]byte{ST7735_MADCTL}, []byte{0xC8}, 0},                                          // memory access control (directions), row addr/col addr, bottom to top refresh
-		{[]byte{ST7735_COLMOD}, []byte{0x05}, 0},                                          // set color mode, 16-bit color
-		{[]byte{ST7735_CASET}, []byte{0x00, offsetLeft, 0x00, width + offsetLeft - 1}, 0}, // Column addr set, XSTART = 0, XEND = ROWS-height
-		{[]byte{ST7735_RASET}, []byte{0x00, offsetTop, 0x00, height + offsetTop - 1}, 0},  // Row addr set, XSTART = 0, XEND = COLS-width
-		{[]byte{ST7735_GMCTRP1}, []byte{0x02, 0x1c, 0x07, 0x12, // set gamma
-			0x37, 0x32, 0x29, 0x2d, 0x29, 0x25, 0x2B, 0x39, 0x00,
-			0x01, 0x03, 0x10}, 0},
-		{[]byte{ST7735_GMCTRN1}, []byte{0x03, 0x1d, 0x07, 0x06, // set gamma
-			0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00,
-			0x00, 0x02, 0x10}, 0},
+		{[]byte{ST7735_COLMOD}, []byte{0x05}, 0},             // set color mode, 16-bit color
+		{[]byte{ST7735_GMCTRP1}, d.spec.gammaP, 0},           // set gamma, per variant
+		{[]byte{ST7735_GMCTRN1}, d.spec.gammaN, 0},           // set gamma, per variant
 		{[]byte{ST7735_NORON}, nil, 100 * time.Millisecond},  // normal display on
 		{[]byte{ST7735_DISPON}, nil, 100 * time.Millisecond}, // display on
 	}
 
-	return d.sendBatch(init)
+	if err := d.sendBatch(init); err != nil {
+		return err
+	}
+
+	return d.SetOrientation(d.opts.Orientation)
+}
+
+// applyOrientation recomputes the effective width/height/offsets for o
+// without talking to the device, used before the device has been initialized.
+func (d *Dev) applyOrientation(o Orientation) {
+	d.opts.Orientation = o
+
+	if o.swapsDimensions() {
+		d.width = d.opts.Height
+		d.height = d.opts.Width
+		d.offsetLeft = d.opts.OffsetTop
+		d.offsetTop = d.opts.OffsetLeft
+	} else {
+		d.width = d.opts.Width
+		d.height = d.opts.Height
+		d.offsetLeft = d.opts.OffsetLeft
+		d.offsetTop = d.opts.OffsetTop
+	}
+}
+
+// SetOrientation rotates the panel by writing MADCTL and swaps the effective
+// width/height and offsets used by SetWindow/DisplayImage when the rotation
+// exchanges rows and columns.
+func (d *Dev) SetOrientation(o Orientation) error {
+	d.applyOrientation(o)
+
+	return d.sendBatch([]commandAndData{
+		{[]byte{ST7735_MADCTL}, []byte{o.madctl(d.spec.bgr)}, 0},
+	})
 }
 
 func (d *Dev) Powersave() error {
@@ -149,11 +273,11 @@ func (d *Dev) SetBacklight(value bool) {
 }
 
 func (d *Dev) SetWindow(x0, y0, x1, y1 int) error {
-	y0 += int(d.opts.OffsetTop)
-	y1 += int(d.opts.OffsetTop)
+	y0 += int(d.offsetTop)
+	y1 += int(d.offsetTop)
 
-	x0 += int(d.opts.OffsetLeft)
-	x1 += int(d.opts.OffsetLeft)
+	x0 += int(d.offsetLeft)
+	x1 += int(d.offsetLeft)
 
 	commands := []commandAndData{
 		{[]byte{ST7735_CASET}, []byte{byte(x0 >> 8), byte(x0), byte(x1 >> 8), byte(x1)}, 0}, // column addr set
@@ -166,33 +290,12 @@ func (d *Dev) SetWindow(x0, y0, x1, y1 int) error {
 }
 
 func (d *Dev) DisplayImage(x, y int, img *image.RGBA) error {
-	offsetBounds := img.Bounds().Add(image.Point{X: x, Y: y})
-	maxBounds := image.Rectangle{Min: image.Point{0, 0}, Max: image.Point{int(d.opts.Width), int(d.opts.Height)}}
-	offsetBounds = offsetBounds.Intersect(maxBounds)
-	bounds := offsetBounds.Sub(image.Point{X: x, Y: y})
-
-	err := d.SetWindow(offsetBounds.Min.X, offsetBounds.Min.Y, offsetBounds.Max.X-1, offsetBounds.Max.Y-1)
-	if err != nil {
-		return err
-	}
-
-	subImg := img.SubImage(bounds)
-
-	buf := []byte{}
-	for x := bounds.Min.X; x < bounds.Max.X; x++ {
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			c := subImg.At(x, y)
-
-			cd := RGBATo565(c.(color.RGBA))
-			buf = append(buf, byte(cd>>8), byte(cd))
-		}
-	}
-
-	return d.sendData(buf)
+	r := img.Bounds().Add(image.Point{X: x, Y: y})
+	return d.Draw(r, img, img.Bounds().Min)
 }
 
 func (d *Dev) Display(data []byte) error {
-	err := d.SetWindow(0, 0, int(d.opts.Width-1), int(d.opts.Height-1))
+	err := d.SetWindow(0, 0, int(d.width-1), int(d.height-1))
 	if err != nil {
 		return err
 	}
@@ -200,6 +303,11 @@ func (d *Dev) Display(data []byte) error {
 	return d.sendData(data)
 }
 
+// String implements conn.Resource.
+func (d *Dev) String() string {
+	return fmt.Sprintf("st7735.Dev{%s, %dx%d}", d.c, d.width, d.height)
+}
+
 func (d *Dev) Halt() error {
 	d.SetBacklight(false)
 	return d.Powersave()
@@ -261,6 +369,13 @@ type commandAndData struct {
 
 // RGBATo565 converts a color.RGBA to uint16 used in the display
 func RGBATo565(c color.RGBA) uint16 {
+	return ColorToRGB565(c)
+}
+
+// ColorToRGB565 converts any color.Color to the uint16 RGB565 value used by
+// the display, unlike RGBATo565 this does not require the color to already
+// be a color.RGBA.
+func ColorToRGB565(c color.Color) uint16 {
 	r, g, b, _ := c.RGBA()
 	return uint16((r & 0xF800) +
 		((g & 0xFC00) >> 5) +
@@ -0,0 +1,124 @@
+package st7735
+
+import "fmt"
+
+// Variant identifies a specific ST7735 panel/tab revision. Panels differ in
+// addressable area, column/row offset into the controller's RAM, color
+// order and gamma tuning, mirroring the variant handling found in the
+// Adafruit and Rust st7735-lcd drivers.
+type Variant byte
+
+const (
+	// VariantGreenTab is the common 128x160, 1.8" "green tab" panel.
+	VariantGreenTab Variant = iota
+	// VariantRedTab is the 128x160, 1.8" "red tab" panel.
+	VariantRedTab
+	// VariantBlackTab is the 128x160, 1.8" "black tab" panel, which uses RGB
+	// (not BGR) color order.
+	VariantBlackTab
+	// Variant144GreenTab is the 128x128, 1.44" "green tab" panel.
+	Variant144GreenTab
+	// Variant80x160 is the 0.96", 80x160 panel assumed by this package
+	// before variant support was added.
+	Variant80x160
+)
+
+// variantSpec holds the per-variant defaults and command table entries
+// needed by Init.
+type variantSpec struct {
+	width, height  byte
+	cols, rows     byte // addressable area in the controller's RAM
+	offsetLeft     byte
+	offsetTop      byte
+	bgr            bool // color order, true = BGR, false = RGB
+	gammaP, gammaN []byte
+}
+
+var greenTabGamma = struct{ p, n []byte }{
+	p: []byte{0x02, 0x1c, 0x07, 0x12,
+		0x37, 0x32, 0x29, 0x2d, 0x29, 0x25, 0x2B, 0x39, 0x00,
+		0x01, 0x03, 0x10},
+	n: []byte{0x03, 0x1d, 0x07, 0x06,
+		0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E, 0x37, 0x3F, 0x00,
+		0x00, 0x02, 0x10},
+}
+
+var blackTabGamma = struct{ p, n []byte }{
+	p: []byte{0x0f, 0x1a, 0x0f, 0x18,
+		0x2f, 0x28, 0x20, 0x22, 0x1f, 0x1b, 0x23, 0x37, 0x00,
+		0x07, 0x02, 0x10},
+	n: []byte{0x0f, 0x1b, 0x0f, 0x17,
+		0x33, 0x2c, 0x29, 0x2e, 0x30, 0x30, 0x39, 0x3f, 0x00,
+		0x07, 0x03, 0x10},
+}
+
+var variants = map[Variant]variantSpec{
+	VariantGreenTab: {
+		width: 128, height: 160,
+		cols: 132, rows: 162,
+		offsetLeft: 2, offsetTop: 1,
+		bgr:    true,
+		gammaP: greenTabGamma.p, gammaN: greenTabGamma.n,
+	},
+	VariantRedTab: {
+		width: 128, height: 160,
+		cols: 128, rows: 160,
+		offsetLeft: 0, offsetTop: 0,
+		bgr:    true,
+		gammaP: greenTabGamma.p, gammaN: greenTabGamma.n,
+	},
+	VariantBlackTab: {
+		width: 128, height: 160,
+		cols: 132, rows: 162,
+		offsetLeft: 2, offsetTop: 3,
+		bgr:    false,
+		gammaP: blackTabGamma.p, gammaN: blackTabGamma.n,
+	},
+	Variant144GreenTab: {
+		width: 128, height: 128,
+		cols: 128, rows: 128,
+		offsetLeft: 2, offsetTop: 3,
+		bgr:    true,
+		gammaP: greenTabGamma.p, gammaN: greenTabGamma.n,
+	},
+	Variant80x160: {
+		width: 80, height: 160,
+		cols: 132, rows: 162,
+		offsetLeft: 26, offsetTop: 1,
+		bgr:    true,
+		gammaP: greenTabGamma.p, gammaN: greenTabGamma.n,
+	},
+}
+
+// spec looks up the variantSpec for v, falling back to Variant80x160 (this
+// package's original panel) if v is not recognized.
+func (v Variant) spec() variantSpec {
+	if s, ok := variants[v]; ok {
+		return s
+	}
+	return variants[Variant80x160]
+}
+
+// DefaultOptsForVariant returns the Opts this package would use for v if
+// none of Width/Height/OffsetLeft/OffsetTop are overridden.
+func DefaultOptsForVariant(v Variant) Opts {
+	s := v.spec()
+	return Opts{
+		Width:      s.width,
+		Height:     s.height,
+		OffsetLeft: s.offsetLeft,
+		OffsetTop:  s.offsetTop,
+		Variant:    v,
+	}
+}
+
+// validate reports an error if width/height don't fit the variant's
+// addressable area.
+func (v Variant) validate(width, height byte) error {
+	s := v.spec()
+	if width > s.cols || height > s.rows {
+		return fmt.Errorf("width/height %dx%d exceed variant's addressable area %dx%d", width, height, s.cols, s.rows)
+	}
+
+	return nil
+}
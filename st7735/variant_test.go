@@ -0,0 +1,73 @@
+package st7735
+
+import "testing"
+
+func TestVariantSpecKnownVariants(t *testing.T) {
+	cases := []struct {
+		v             Variant
+		width, height byte
+		cols, rows    byte
+		offsetLeft    byte
+		offsetTop     byte
+		bgr           bool
+	}{
+		{VariantGreenTab, 128, 160, 132, 162, 2, 1, true},
+		{VariantRedTab, 128, 160, 128, 160, 0, 0, true},
+		{VariantBlackTab, 128, 160, 132, 162, 2, 3, false},
+		{Variant144GreenTab, 128, 128, 128, 128, 2, 3, true},
+		{Variant80x160, 80, 160, 132, 162, 26, 1, true},
+	}
+
+	for _, c := range cases {
+		s := c.v.spec()
+		if s.width != c.width || s.height != c.height {
+			t.Errorf("%v: width/height = %d/%d, want %d/%d", c.v, s.width, s.height, c.width, c.height)
+		}
+		if s.cols != c.cols || s.rows != c.rows {
+			t.Errorf("%v: cols/rows = %d/%d, want %d/%d", c.v, s.cols, s.rows, c.cols, c.rows)
+		}
+		if s.offsetLeft != c.offsetLeft || s.offsetTop != c.offsetTop {
+			t.Errorf("%v: offsetLeft/offsetTop = %d/%d, want %d/%d", c.v, s.offsetLeft, s.offsetTop, c.offsetLeft, c.offsetTop)
+		}
+		if s.bgr != c.bgr {
+			t.Errorf("%v: bgr = %v, want %v", c.v, s.bgr, c.bgr)
+		}
+		if len(s.gammaP) == 0 || len(s.gammaN) == 0 {
+			t.Errorf("%v: gammaP/gammaN must not be empty", c.v)
+		}
+	}
+}
+
+func TestVariantSpecUnknownFallsBackToVariant80x160(t *testing.T) {
+	unknown := Variant(0xff)
+	got, want := unknown.spec(), Variant80x160.spec()
+	if got.width != want.width || got.height != want.height || got.cols != want.cols || got.rows != want.rows {
+		t.Errorf("unknown variant spec = %+v, want fallback %+v", got, want)
+	}
+}
+
+func TestVariantValidate(t *testing.T) {
+	if err := Variant80x160.validate(80, 160); err != nil {
+		t.Errorf("validate(80, 160) = %v, want nil", err)
+	}
+	if err := Variant80x160.validate(132, 162); err != nil {
+		t.Errorf("validate(132, 162) = %v, want nil (exactly the addressable area)", err)
+	}
+	if err := Variant80x160.validate(200, 200); err == nil {
+		t.Error("validate(200, 200) = nil, want an error (exceeds addressable area)")
+	}
+}
+
+func TestDefaultOptsForVariant(t *testing.T) {
+	o := DefaultOptsForVariant(VariantBlackTab)
+	s := VariantBlackTab.spec()
+	if o.Width != s.width || o.Height != s.height {
+		t.Errorf("Width/Height = %d/%d, want %d/%d", o.Width, o.Height, s.width, s.height)
+	}
+	if o.OffsetLeft != s.offsetLeft || o.OffsetTop != s.offsetTop {
+		t.Errorf("OffsetLeft/OffsetTop = %d/%d, want %d/%d", o.OffsetLeft, o.OffsetTop, s.offsetLeft, s.offsetTop)
+	}
+	if o.Variant != VariantBlackTab {
+		t.Errorf("Variant = %v, want %v", o.Variant, VariantBlackTab)
+	}
+}